@@ -0,0 +1,80 @@
+// Package cmd contains the goreleaser CLI commands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// varsFlag implements flag.Value, collecting repeated `--var key=value`
+// flags into overrides for the `vars:` section of .goreleaser.yaml.
+type varsFlag map[string]string
+
+func (v varsFlag) String() string {
+	parts := make([]string, 0, len(v))
+	for k, val := range v {
+		parts = append(parts, k+"="+val)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (v varsFlag) Set(s string) error {
+	key, val, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid --var %q: expected key=value", s)
+	}
+	v[key] = val
+	return nil
+}
+
+// resolveRunVars resolves the project's declared vars for a release run,
+// preferring --var overrides, then each var's env (read from the real
+// process environment), then each var's default, and only then falling
+// back to interactive prompting when interactive is true. Non-interactive runs (the CI default) fail
+// fast via tmpl.RequiredVarError when a var has no default, env, or --var
+// override.
+func resolveRunVars(vars []config.Var, overrides varsFlag, interactive bool, t *tmpl.Template, prompt tmpl.Prompter) (map[string]string, error) {
+	defs := tmpl.VarDefsFromConfig(vars)
+
+	env := processEnviron()
+
+	// --var overrides take priority over both the process environment and
+	// each var's Default: seed env[def.Env] with the override unconditionally
+	// whenever one is given for this var's Name, synthesizing an Env key for
+	// vars that don't declare one so the override still reaches ResolveVars.
+	for i, d := range defs {
+		v, ok := overrides[d.Name]
+		if !ok {
+			continue
+		}
+		if d.Env == "" {
+			defs[i].Env = d.Name
+		}
+		env[defs[i].Env] = v
+	}
+
+	if !interactive {
+		prompt = nil
+	}
+
+	return tmpl.ResolveVars(t, defs, env, prompt)
+}
+
+// processEnviron returns the current process environment as a map, so vars
+// declaring env: pick up values actually set in CI rather than only ever
+// seeing --var overrides.
+func processEnviron() map[string]string {
+	environ := os.Environ()
+	out := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		k, v, ok := strings.Cut(kv, "=")
+		if ok {
+			out[k] = v
+		}
+	}
+	return out
+}
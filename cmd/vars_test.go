@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/goreleaser/goreleaser/internal/tmpl"
+	"github.com/goreleaser/goreleaser/pkg/config"
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+func newTestTemplate() *tmpl.Template {
+	return tmpl.New(context.New(context.Config{ProjectName: "foo"}))
+}
+
+func TestResolveRunVarsOverrideWinsOverEnvAndDefault(t *testing.T) {
+	os.Setenv("TEST_FLAVOR", "from-process")
+	defer os.Unsetenv("TEST_FLAVOR")
+
+	vars := []config.Var{
+		{Name: "Flavor", Default: "community", Env: "TEST_FLAVOR"},
+	}
+	overrides := varsFlag{"Flavor": "enterprise"}
+
+	resolved, err := resolveRunVars(vars, overrides, false, newTestTemplate(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "enterprise" {
+		t.Errorf("got Flavor=%q, want override to win", resolved["Flavor"])
+	}
+}
+
+func TestResolveRunVarsReadsProcessEnv(t *testing.T) {
+	os.Setenv("TEST_FLAVOR", "from-process")
+	defer os.Unsetenv("TEST_FLAVOR")
+
+	vars := []config.Var{
+		{Name: "Flavor", Default: "community", Env: "TEST_FLAVOR"},
+	}
+
+	resolved, err := resolveRunVars(vars, varsFlag{}, false, newTestTemplate(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "from-process" {
+		t.Errorf("got Flavor=%q, want value from process env", resolved["Flavor"])
+	}
+}
+
+func TestResolveRunVarsFallsBackToDefault(t *testing.T) {
+	os.Unsetenv("TEST_FLAVOR")
+
+	vars := []config.Var{
+		{Name: "Flavor", Default: "community", Env: "TEST_FLAVOR"},
+	}
+
+	resolved, err := resolveRunVars(vars, varsFlag{}, false, newTestTemplate(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "community" {
+		t.Errorf("got Flavor=%q, want default", resolved["Flavor"])
+	}
+}
@@ -0,0 +1,45 @@
+// Package git provides the pipe that gathers git repository information
+// (tags, commits, working-tree state) into the run context.
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/goreleaser/goreleaser/pkg/context"
+)
+
+// Pipe for git.
+type Pipe struct{}
+
+func (Pipe) String() string { return "getting git state" }
+
+// Run the pipe.
+func (Pipe) Run(ctx *context.Context) error {
+	dirty, err := isDirty()
+	if err != nil {
+		return fmt.Errorf("failed to check git tree state: %w", err)
+	}
+
+	ctx.Git.IsDirty = dirty
+	ctx.Git.TreeState = treeState(dirty)
+	return nil
+}
+
+// isDirty runs `git status --porcelain` and reports whether it printed any
+// output, i.e. whether the working tree has uncommitted changes.
+func isDirty() (bool, error) {
+	out, err := exec.Command("git", "status", "--porcelain").Output()
+	if err != nil {
+		return false, err
+	}
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+func treeState(dirty bool) string {
+	if dirty {
+		return "dirty"
+	}
+	return "clean"
+}
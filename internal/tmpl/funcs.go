@@ -0,0 +1,227 @@
+package tmpl
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// FuncMap returns the set of functions made available to every template
+// evaluated via Apply. It is exported so other packages (and tests) can
+// introspect or extend the supported set without duplicating it.
+//
+// The set is intentionally curated rather than a full Sprig import: each
+// addition here is a deliberate, documented decision, so a future helper
+// doesn't silently change the meaning of an existing user template.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"replace":    strings.ReplaceAll,
+		"tolower":    strings.ToLower,
+		"toupper":    strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"trimprefix": strings.TrimPrefix,
+		"trimsuffix": strings.TrimSuffix,
+		"dir":        filepath.Dir,
+		"abs":        filepath.Abs,
+		"time": func(s string) string {
+			return time.Now().UTC().Format(s)
+		},
+
+		// string manipulation
+		"title":     strings.Title, //nolint:staticcheck
+		"snake":     toSnakeCase,
+		"kebab":     toKebabCase,
+		"camel":     toCamelCase,
+		"contains":  strings.Contains,
+		"hasPrefix": strings.HasPrefix,
+		"hasSuffix": strings.HasSuffix,
+		"split":     strings.Split,
+		"join":      strings.Join,
+		"repeat": func(count int, s string) string {
+			return strings.Repeat(s, count)
+		},
+		"indent":  indent,
+		"nindent": nindent,
+
+		// path helpers
+		"base":  filepath.Base,
+		"clean": filepath.Clean,
+		"ext":   filepath.Ext,
+
+		// encoding
+		"b64enc":    b64enc,
+		"b64dec":    b64dec,
+		"sha256sum": sha256sum,
+		"sha1sum":   sha1sum,
+
+		// date helpers
+		"dateInZone": dateInZone,
+		"now": func() string {
+			return time.Now().UTC().Format(time.RFC3339)
+		},
+		"ago": ago,
+
+		// conditional helpers
+		"default":  dfault,
+		"coalesce": coalesce,
+		"ternary":  ternary,
+		"empty":    empty,
+	}
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+func nindent(spaces int, s string) string {
+	return "\n" + indent(spaces, s)
+}
+
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func b64dec(s string) (string, error) {
+	out, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func sha256sum(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func sha1sum(s string) string {
+	h := sha1.Sum([]byte(s)) //nolint:gosec
+	return hex.EncodeToString(h[:])
+}
+
+func dateInZone(format, zone, t string) (string, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", err
+	}
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return "", err
+	}
+	return parsed.In(loc).Format(format), nil
+}
+
+func ago(t string) (string, error) {
+	parsed, err := time.Parse(time.RFC3339, t)
+	if err != nil {
+		return "", err
+	}
+	return time.Since(parsed).Round(time.Second).String(), nil
+}
+
+// dfault returns d if given is the zero value for its type, otherwise given.
+func dfault(d, given interface{}) interface{} {
+	if empty(given) {
+		return d
+	}
+	return given
+}
+
+// coalesce returns the first of vals that is non-empty, or nil.
+func coalesce(vals ...interface{}) interface{} {
+	for _, v := range vals {
+		if !empty(v) {
+			return v
+		}
+	}
+	return nil
+}
+
+func ternary(trueVal, falseVal interface{}, cond bool) interface{} {
+	if cond {
+		return trueVal
+	}
+	return falseVal
+}
+
+// empty reports whether v is the zero value for its type, matching sprig's
+// semantics: covers every Fields value we actually produce (the numeric
+// Major/Minor/Patch int64s, the Unix() int64 timestamps, string/bool
+// fields, and any slice/map/pointer a user adds via WithExtraFields).
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map, reflect.Chan, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return empty(rv.Elem().Interface())
+	}
+	return false
+}
+
+func toSnakeCase(s string) string {
+	return toDelimited(s, '_')
+}
+
+func toKebabCase(s string) string {
+	return toDelimited(s, '-')
+}
+
+func toDelimited(s string, delimiter rune) string {
+	var sb strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if i > 0 && isUpper(r) && (isLower(runes[i-1]) || (i+1 < len(runes) && isLower(runes[i+1]))) {
+			sb.WriteRune(delimiter)
+		}
+		sb.WriteRune(toLowerRune(r))
+	}
+	return sb.String()
+}
+
+func toCamelCase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	var sb strings.Builder
+	for i, p := range parts {
+		if i == 0 {
+			sb.WriteString(strings.ToLower(p))
+			continue
+		}
+		sb.WriteString(strings.Title(strings.ToLower(p))) //nolint:staticcheck
+	}
+	return sb.String()
+}
+
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+func isLower(r rune) bool { return r >= 'a' && r <= 'z' }
+
+func toLowerRune(r rune) rune {
+	if isUpper(r) {
+		return r + ('a' - 'A')
+	}
+	return r
+}
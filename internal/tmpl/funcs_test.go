@@ -0,0 +1,113 @@
+package tmpl
+
+import "testing"
+
+func TestEmpty(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   interface{}
+		want bool
+	}{
+		{"nil", nil, true},
+		{"empty string", "", true},
+		{"non-empty string", "x", false},
+		{"false", false, true},
+		{"true", true, false},
+		{"zero int", 0, true},
+		{"non-zero int", 1, false},
+		{"zero int64", int64(0), true},
+		{"non-zero int64", int64(5), false},
+		{"zero uint64", uint64(0), true},
+		{"non-zero uint64", uint64(5), false},
+		{"zero float64", float64(0), true},
+		{"non-zero float64", 1.5, false},
+		{"empty slice", []string{}, true},
+		{"non-empty slice", []string{"a"}, false},
+		{"empty map", map[string]string{}, true},
+		{"non-empty map", map[string]string{"a": "b"}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := empty(tc.in); got != tc.want {
+				t.Errorf("empty(%#v) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if got := dfault("fallback", ""); got != "fallback" {
+		t.Errorf("got %v", got)
+	}
+	if got := dfault("fallback", "value"); got != "value" {
+		t.Errorf("got %v", got)
+	}
+	if got := dfault(1, int64(0)); got != 1 {
+		t.Errorf("got %v", got)
+	}
+	if got := dfault(1, int64(5)); got != int64(5) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	if got := coalesce("", 0, "third"); got != "third" {
+		t.Errorf("got %v", got)
+	}
+	if got := coalesce("", int64(0)); got != nil {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestTernary(t *testing.T) {
+	if got := ternary("yes", "no", true); got != "yes" {
+		t.Errorf("got %v", got)
+	}
+	if got := ternary("yes", "no", false); got != "no" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestStringHelpers(t *testing.T) {
+	if got := toSnakeCase("FooBar"); got != "foo_bar" {
+		t.Errorf("snake: got %q", got)
+	}
+	if got := toKebabCase("FooBar"); got != "foo-bar" {
+		t.Errorf("kebab: got %q", got)
+	}
+	if got := toCamelCase("foo_bar"); got != "fooBar" {
+		t.Errorf("camel: got %q", got)
+	}
+	if got := indent(2, "a\nb"); got != "  a\n  b" {
+		t.Errorf("indent: got %q", got)
+	}
+	if got := nindent(2, "a"); got != "\n  a" {
+		t.Errorf("nindent: got %q", got)
+	}
+}
+
+func TestEncodingHelpers(t *testing.T) {
+	enc := b64enc("hello")
+	dec, err := b64dec(enc)
+	if err != nil || dec != "hello" {
+		t.Errorf("b64 roundtrip failed: dec=%q err=%v", dec, err)
+	}
+	if sha256sum("hello") != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("unexpected sha256sum")
+	}
+	if sha1sum("hello") != "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d" {
+		t.Errorf("unexpected sha1sum")
+	}
+}
+
+func TestApplyDefaultOnNumericField(t *testing.T) {
+	tpl := newTestTemplate()
+	tpl.fields[major] = int64(0)
+
+	out, err := tpl.Apply(`{{ .Major | default 1 }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "1" {
+		t.Errorf("got %q", out)
+	}
+}
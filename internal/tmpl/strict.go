@@ -0,0 +1,299 @@
+package tmpl
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// UnknownFieldError is returned by ApplyStrict when a template references a
+// field that isn't part of the known Fields schema.
+type UnknownFieldError struct {
+	Field      string
+	Line       int
+	Suggestion string
+}
+
+func (e UnknownFieldError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("tmpl: unknown field %q at line %d", e.Field, e.Line)
+	}
+	return fmt.Sprintf("tmpl: unknown field %q at line %d (did you mean %q?)", e.Field, e.Line, e.Suggestion)
+}
+
+// knownFieldsSchema lists every top-level field Apply may ever populate.
+// It holds top-level names only: the known sub-fields of struct-valued
+// fields are in nestedSchemas, and any sub-field of the maps in
+// openEndedFields (Env, GoEnv, Vars) is allowed without a check.
+func knownFieldsSchema() map[string]bool {
+	return map[string]bool{
+		projectName:        true,
+		version:            true,
+		rawVersion:         true,
+		tag:                true,
+		branch:             true,
+		commit:             true,
+		shortCommit:        true,
+		fullCommit:         true,
+		commitDate:         true,
+		commitTimestamp:    true,
+		gitURL:             true,
+		major:              true,
+		minor:              true,
+		patch:              true,
+		prerelease:         true,
+		isSnapshot:         true,
+		env:                true,
+		envOr:              true,
+		envInt:             true,
+		date:               true,
+		timestamp:          true,
+		git:                true,
+		goEnvKey:           true,
+		osKey:              true,
+		arch:               true,
+		arm:                true,
+		mips:               true,
+		binary:             true,
+		artifactName:       true,
+		artifactPath:       true,
+		artifactUploadHash: true,
+		name:               true,
+		ext:                true,
+		path:               true,
+		target:             true,
+		"Vars":             true,
+	}
+}
+
+// openEndedFields are maps whose sub-fields are not known ahead of time
+// (they come from the environment, go env output, or user-declared vars),
+// so ApplyStrict does not validate field names one level below these.
+var openEndedFields = map[string]bool{
+	env:      true,
+	goEnvKey: true,
+	"Vars":   true,
+}
+
+// nestedSchemas holds the known sub-fields of fields whose value is itself
+// a struct, so e.g. `.Git.Bogus` is caught one level deep, the same as a
+// bad top-level field.
+var nestedSchemas = map[string]map[string]bool{
+	git: {
+		"IsDirty":   true,
+		"IsClean":   true,
+		"TreeState": true,
+	},
+}
+
+// ApplyStrict behaves like Apply, but first walks the template's AST and
+// validates every field reference against the known Fields schema,
+// returning an UnknownFieldError (with a Levenshtein-based suggestion) for
+// the first one that doesn't match instead of deferring to Execute's
+// generic "<no value>"/missingkey error.
+func (t *Template) ApplyStrict(s string) (string, error) {
+	tmpl, err := template.New("tmpl").
+		Option("missingkey=error").
+		Funcs(FuncMap()).
+		Parse(s)
+	if err != nil {
+		return "", err
+	}
+
+	if err := checkFields(tmpl.Tree.Root, knownFieldsSchema(), s); err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	err = tmpl.Execute(&out, t.fields)
+	return out.String(), err
+}
+
+// checkFields walks node validating every field reference against known,
+// the schema for whatever "." currently refers to. known is nil when "."
+// has been rebound (by a with/range) to something we can't statically
+// resolve a schema for, in which case field references below it are left
+// unchecked rather than validated against the wrong scope.
+func checkFields(node parse.Node, known map[string]bool, src string) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkFields(c, known, src); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		return checkFields(n.Pipe, known, src)
+	case *parse.IfNode:
+		// "." is not rebound by if, so both branches share the outer scope.
+		if err := checkFields(n.Pipe, known, src); err != nil {
+			return err
+		}
+		if err := checkFields(n.List, known, src); err != nil {
+			return err
+		}
+		return checkFields(n.ElseList, known, src)
+	case *parse.RangeNode:
+		if err := checkFields(n.Pipe, known, src); err != nil {
+			return err
+		}
+		// "." inside the loop body is each element, whose shape we can't
+		// statically know here, so don't validate fields against it.
+		if err := checkFields(n.List, nil, src); err != nil {
+			return err
+		}
+		// a zero-iteration else runs with "." unchanged.
+		return checkFields(n.ElseList, known, src)
+	case *parse.WithNode:
+		if err := checkFields(n.Pipe, known, src); err != nil {
+			return err
+		}
+		if err := checkFields(n.List, rebind(n.Pipe, known), src); err != nil {
+			return err
+		}
+		// with's else runs with "." unchanged, same as if.
+		return checkFields(n.ElseList, known, src)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				if err := checkFields(arg, known, src); err != nil {
+					return err
+				}
+			}
+		}
+	case *parse.FieldNode:
+		return checkFieldChain(n.Ident, lineAt(src, n.Position()), known)
+	case *parse.ChainNode:
+		return checkFieldChain(n.Field, lineAt(src, n.Position()), known)
+	}
+	return nil
+}
+
+// rebind computes the field schema "." is rebound to inside a with body,
+// given the outer schema and the with's pipe. It only resolves the simple,
+// common case of a with over a single field reference (e.g. `with .Git`);
+// anything else (a func call, a var, an open-ended field, an unrecognized
+// nested field) falls back to nil, meaning "don't validate further".
+func rebind(pipe *parse.PipeNode, outer map[string]bool) map[string]bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return nil
+	}
+
+	var idents []string
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.FieldNode:
+		idents = arg.Ident
+	case *parse.ChainNode:
+		idents = arg.Field
+	default:
+		return nil
+	}
+	if len(idents) != 1 {
+		return nil
+	}
+
+	head := idents[0]
+	if outer == nil || !outer[head] {
+		return nil
+	}
+	return nestedSchemas[head]
+}
+
+// lineAt returns the 1-indexed line of pos within src.
+func lineAt(src string, pos parse.Pos) int {
+	if int(pos) > len(src) {
+		return 1 + strings.Count(src, "\n")
+	}
+	return 1 + strings.Count(src[:pos], "\n")
+}
+
+func checkFieldChain(idents []string, line int, known map[string]bool) error {
+	if len(idents) == 0 || known == nil {
+		return nil
+	}
+
+	head := idents[0]
+	if !known[head] {
+		return UnknownFieldError{Field: head, Line: line, Suggestion: closestField(head, known)}
+	}
+	if len(idents) == 1 {
+		return nil
+	}
+
+	if sub, ok := nestedSchemas[head]; ok {
+		if !sub[idents[1]] {
+			return UnknownFieldError{
+				Field:      head + "." + idents[1],
+				Line:       line,
+				Suggestion: closestField(idents[1], sub),
+			}
+		}
+		return nil
+	}
+
+	if openEndedFields[head] {
+		return nil
+	}
+
+	return UnknownFieldError{Field: head + "." + idents[1], Line: line}
+}
+
+// closestField returns the known field with the smallest Levenshtein
+// distance to field, or "" if none is reasonably close.
+func closestField(field string, known map[string]bool) string {
+	best := ""
+	bestDist := -1
+	for candidate := range known {
+		d := levenshtein(strings.ToLower(field), strings.ToLower(candidate))
+		if bestDist == -1 || d < bestDist {
+			bestDist = d
+			best = candidate
+		}
+	}
+	if bestDist < 0 || bestDist > 3 {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+
+	prev := make([]int, lb+1)
+	curr := make([]int, lb+1)
+	for j := 0; j <= lb; j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= la; i++ {
+		curr[0] = i
+		for j := 1; j <= lb; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[lb]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
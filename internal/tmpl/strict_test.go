@@ -0,0 +1,61 @@
+package tmpl
+
+import "testing"
+
+func newStrictTestTemplate() *Template {
+	return &Template{
+		fields: Fields{
+			projectName: "foo",
+			git: GitInfo{
+				IsDirty:   true,
+				IsClean:   false,
+				TreeState: "dirty",
+			},
+		},
+	}
+}
+
+func TestApplyStrictWithRebindsDot(t *testing.T) {
+	tpl := newStrictTestTemplate()
+
+	out, err := tpl.ApplyStrict(`{{ with .Git }}{{ .TreeState }}{{ end }}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "dirty" {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestApplyStrictRangeDoesNotValidateElementDot(t *testing.T) {
+	tpl := newStrictTestTemplate()
+	tpl.fields[goEnvKey] = map[string]string{"GOOS": "linux"}
+
+	if _, err := tpl.ApplyStrict(`{{ range .GoEnv }}{{ . }}{{ end }}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestApplyStrictUnknownNestedField(t *testing.T) {
+	tpl := newStrictTestTemplate()
+
+	_, err := tpl.ApplyStrict(`{{ .Git.Bogus }}`)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	uerr, ok := err.(UnknownFieldError)
+	if !ok {
+		t.Fatalf("expected UnknownFieldError, got %T: %v", err, err)
+	}
+	if uerr.Field != "Git.Bogus" {
+		t.Errorf("got field %q", uerr.Field)
+	}
+}
+
+func TestApplyStrictUnknownTopLevelField(t *testing.T) {
+	tpl := newStrictTestTemplate()
+
+	if _, err := tpl.ApplyStrict(`{{ .ProjectNme }}`); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
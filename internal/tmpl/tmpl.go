@@ -4,9 +4,12 @@ package tmpl
 import (
 	"bytes"
 	"fmt"
-	"path/filepath"
+	"os/exec"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -23,6 +26,30 @@ type Template struct {
 // Fields that will be available to the template engine.
 type Fields map[string]interface{}
 
+// EnvOr returns the value of the given environment variable, or fallback if
+// it isn't set. It is a method (rather than a plain map entry) so that
+// text/template lets it be called with arguments as {{ .EnvOr "VAR" "fallback" }}:
+// a func stored as a map value can only be referenced, not called with args.
+func (f Fields) EnvOr(key, fallback string) string {
+	e, _ := f[env].(map[string]string)
+	if v, ok := e[key]; ok {
+		return v
+	}
+	return fallback
+}
+
+// EnvInt returns the value of the given environment variable parsed as an
+// int, erroring if it is unset or not a valid int. See EnvOr for why this
+// is a method rather than a map entry.
+func (f Fields) EnvInt(key string) (int, error) {
+	e, _ := f[env].(map[string]string)
+	v, ok := e[key]
+	if !ok {
+		return 0, fmt.Errorf("env: %q is not set", key)
+	}
+	return strconv.Atoi(v)
+}
+
 const (
 	// general keys.
 	projectName     = "ProjectName"
@@ -42,8 +69,12 @@ const (
 	prerelease      = "Prerelease"
 	isSnapshot      = "IsSnapshot"
 	env             = "Env"
+	envOr           = "EnvOr"
+	envInt          = "EnvInt"
 	date            = "Date"
 	timestamp       = "Timestamp"
+	git             = "Git"
+	goEnvKey        = "GoEnv"
 
 	// artifact-only keys.
 	osKey        = "Os"
@@ -64,6 +95,18 @@ const (
 	target = "Target"
 )
 
+// GitInfo exposes git working-tree state as a nested template field, so
+// users can write e.g. {{ .Git.IsDirty }} or {{ .Git.TreeState }}.
+//
+// The actual status check (git status --porcelain) is run once by the git
+// pipe and cached on context.Git; this struct only mirrors that result into
+// the template fields.
+type GitInfo struct {
+	IsDirty   bool
+	IsClean   bool
+	TreeState string
+}
+
 // New Template.
 func New(ctx *context.Context) *Template {
 	sv := ctx.Semver
@@ -90,10 +133,43 @@ func New(ctx *context.Context) *Template {
 			patch:           ctx.Semver.Patch,
 			prerelease:      ctx.Semver.Prerelease,
 			isSnapshot:      ctx.Snapshot,
+			git: GitInfo{
+				IsDirty:   ctx.Git.IsDirty,
+				IsClean:   !ctx.Git.IsDirty,
+				TreeState: ctx.Git.TreeState,
+			},
+			goEnvKey: goEnv(),
 		},
 	}
 }
 
+var (
+	goEnvOnce   sync.Once
+	goEnvResult map[string]string
+)
+
+// goEnv runs `go env` once per process and caches the result, exposing the
+// keys users most commonly need for build provenance (GOOS, GOARCH,
+// GOVERSION, GOPATH, CGO_ENABLED) as the GoEnv template field.
+func goEnv() map[string]string {
+	goEnvOnce.Do(func() {
+		goEnvResult = map[string]string{}
+		keys := []string{"GOOS", "GOARCH", "GOVERSION", "GOPATH", "CGO_ENABLED"}
+		out, err := exec.Command("go", append([]string{"env"}, keys...)...).Output()
+		if err != nil {
+			return
+		}
+		lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+		for i, line := range lines {
+			if i >= len(keys) {
+				break
+			}
+			goEnvResult[keys[i]] = line
+		}
+	})
+	return goEnvResult
+}
+
 // WithEnvS overrides template's env field with the given KEY=VALUE list of
 // environment variables.
 func (t *Template) WithEnvS(envs []string) *Template {
@@ -156,24 +232,32 @@ func buildOptsToFields(opts build.Options) Fields {
 	}
 }
 
-// Apply applies the given string against the Fields stored in the template.
-func (t *Template) Apply(s string) (string, error) {
-	var out bytes.Buffer
+// parsedTemplates interns *template.Template instances keyed by their
+// source string, so pipes that call Apply with the same template many
+// times (once per artifact) don't pay to re-parse it each time.
+var parsedTemplates sync.Map
+
+func parseCached(s string) (*template.Template, error) {
+	if cached, ok := parsedTemplates.Load(s); ok {
+		return cached.(*template.Template), nil
+	}
+
 	tmpl, err := template.New("tmpl").
 		Option("missingkey=error").
-		Funcs(template.FuncMap{
-			"replace": strings.ReplaceAll,
-			"time": func(s string) string {
-				return time.Now().UTC().Format(s)
-			},
-			"tolower":    strings.ToLower,
-			"toupper":    strings.ToUpper,
-			"trim":       strings.TrimSpace,
-			"trimprefix": strings.TrimPrefix,
-			"dir":        filepath.Dir,
-			"abs":        filepath.Abs,
-		}).
+		Funcs(FuncMap()).
 		Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := parsedTemplates.LoadOrStore(s, tmpl)
+	return actual.(*template.Template), nil
+}
+
+// Apply applies the given string against the Fields stored in the template.
+func (t *Template) Apply(s string) (string, error) {
+	var out bytes.Buffer
+	tmpl, err := parseCached(s)
 	if err != nil {
 		return "", err
 	}
@@ -182,14 +266,37 @@ func (t *Template) Apply(s string) (string, error) {
 	return out.String(), err
 }
 
+// ApplyAll evaluates every named template in tpls against the same Fields
+// snapshot, returning all results. It short-circuits and returns the first
+// error encountered, along with whatever had been resolved so far. Names
+// are visited in sorted order, so which error surfaces first is stable
+// across runs rather than depending on Go's randomized map iteration.
+func (t *Template) ApplyAll(tpls map[string]string) (map[string]string, error) {
+	names := make([]string, 0, len(tpls))
+	for name := range tpls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(map[string]string, len(tpls))
+	for _, name := range names {
+		v, err := t.Apply(tpls[name])
+		if err != nil {
+			return out, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
 type ExpectedSingleEnvErr struct{}
 
 func (e ExpectedSingleEnvErr) Error() string {
-	return "expected {{ .Env.VAR_NAME }} only (no plain-text or other interpolation)"
+	return `expected {{ .Env.VAR_NAME }} or {{ .EnvOr "VAR_NAME" "" }} or {{ .EnvOr "VAR_NAME" .Env.OTHER_VAR }} only (no plain-text or other interpolation)`
 }
 
-// ApplySingleEnvOnly enforces template to only contain a single environment variable
-// and nothing else.
+// ApplySingleEnvOnly enforces template to only contain a single environment
+// variable access, optionally with a safe EnvOr fallback, and nothing else.
 func (t *Template) ApplySingleEnvOnly(s string) (string, error) {
 	s = strings.TrimSpace(s)
 	if len(s) == 0 {
@@ -199,8 +306,15 @@ func (t *Template) ApplySingleEnvOnly(s string) (string, error) {
 	// text/template/parse (lexer) could be used here too,
 	// but regexp reduces the complexity and should be sufficient,
 	// given the context is mostly discouraging users from bad practice
-	// of hard-coded credentials, rather than catch all possible cases
-	envOnlyRe := regexp.MustCompile(`^{{\s*\.Env\.[^.\s}]+\s*}}$`)
+	// of hard-coded credentials, rather than catch all possible cases.
+	//
+	// EnvOr's fallback argument is restricted to "" or another .Env/.EnvOr
+	// reference: allowing an arbitrary quoted literal there would let a
+	// credential field fall back to a hard-coded secret, which is exactly
+	// what this guard exists to prevent.
+	const envRef = `\.Env\.[^.\s}]+`
+	fallback := `(?:""|` + envRef + `)`
+	envOnlyRe := regexp.MustCompile(`^{{\s*(` + envRef + `|\.EnvOr\s+"[^"]+"\s+` + fallback + `)\s*}}$`)
 	if !envOnlyRe.Match([]byte(s)) {
 		return "", ExpectedSingleEnvErr{}
 	}
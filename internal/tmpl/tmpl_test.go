@@ -0,0 +1,123 @@
+package tmpl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newTestTemplate() *Template {
+	return &Template{
+		fields: Fields{
+			projectName: "foo",
+			version:     "1.2.3",
+			osKey:       "linux",
+			arch:        "amd64",
+		},
+	}
+}
+
+func TestApplySingleEnvOnly(t *testing.T) {
+	tpl := newTestTemplate()
+	tpl.WithEnv(map[string]string{"TOKEN": "abc", "FALLBACK": "def"})
+
+	for _, s := range []string{
+		`{{ .Env.TOKEN }}`,
+		`{{ .EnvOr "TOKEN" "" }}`,
+		`{{ .EnvOr "TOKEN" .Env.FALLBACK }}`,
+	} {
+		if _, err := tpl.ApplySingleEnvOnly(s); err != nil {
+			t.Errorf("%s: unexpected error: %v", s, err)
+		}
+	}
+
+	for _, s := range []string{
+		`{{ .EnvOr "TOKEN" "hardcoded-secret" }}`,
+		`hard-coded-value`,
+		`{{ .Env.TOKEN }}-suffix`,
+	} {
+		if _, err := tpl.ApplySingleEnvOnly(s); err == nil {
+			t.Errorf("%s: expected error, got nil", s)
+		}
+	}
+}
+
+func TestApplyAll(t *testing.T) {
+	tpl := newTestTemplate()
+
+	out, err := tpl.ApplyAll(map[string]string{
+		"name": "{{ .ProjectName }}_{{ .Version }}",
+		"dst":  "{{ .Os }}_{{ .Arch }}",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out["name"] != "foo_1.2.3" {
+		t.Errorf("got %q", out["name"])
+	}
+	if out["dst"] != "linux_amd64" {
+		t.Errorf("got %q", out["dst"])
+	}
+}
+
+func TestApplyAllShortCircuits(t *testing.T) {
+	tpl := newTestTemplate()
+
+	// "aaa_bad" sorts before "zzz_bad": ApplyAll visits names in sorted
+	// order, so the error returned is always for "aaa_bad", regardless of
+	// Go's randomized map iteration order.
+	for i := 0; i < 10; i++ {
+		_, err := tpl.ApplyAll(map[string]string{
+			"ok":      "{{ .ProjectName }}",
+			"aaa_bad": "{{ .DoesNotExist1 }}",
+			"zzz_bad": "{{ .DoesNotExist2 }}",
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.HasPrefix(err.Error(), "aaa_bad:") {
+			t.Fatalf("expected error for aaa_bad (first in sorted order), got: %v", err)
+		}
+	}
+}
+
+// syntheticMatrix returns name_template-style strings representative of a
+// 500-artifact x format release matrix, to benchmark repeated Apply calls
+// against the same small set of distinct template sources.
+func syntheticMatrix(n int) []string {
+	tpls := make([]string, n)
+	for i := range tpls {
+		tpls[i] = fmt.Sprintf("{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}_%d", i%5)
+	}
+	return tpls
+}
+
+func BenchmarkApply(b *testing.B) {
+	tpl := newTestTemplate()
+	tpls := syntheticMatrix(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range tpls {
+			if _, err := tpl.Apply(s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkApplyAll(b *testing.B) {
+	tpl := newTestTemplate()
+	tpls := syntheticMatrix(500)
+	named := make(map[string]string, len(tpls))
+	for i, s := range tpls {
+		named[fmt.Sprintf("artifact-%d", i)] = s
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tpl.ApplyAll(named); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
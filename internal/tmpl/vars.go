@@ -0,0 +1,202 @@
+package tmpl
+
+import (
+	"fmt"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// VarDef describes a single user-declarable variable, as configured under
+// the top-level `vars:` section in .goreleaser.yaml.
+type VarDef struct {
+	// Name is the variable name, exposed to templates as .Vars.<Name>.
+	Name string
+	// Prompt is the question shown to the user in interactive mode.
+	Prompt string
+	// Help is extra context shown alongside Prompt.
+	Help string
+	// Default is a template string, evaluated with Template.Apply once all
+	// of DependsOn has already been resolved.
+	Default string
+	// Env is the name of an environment variable that, when set, overrides
+	// the prompt entirely (used to make CI runs non-interactive).
+	Env string
+	// DependsOn lists other variable names whose resolved values Default
+	// may reference via .Vars.<Name>.
+	DependsOn []string
+}
+
+// VarDefsFromConfig converts the `vars:` section of .goreleaser.yaml into
+// the VarDefs ResolveVars expects.
+func VarDefsFromConfig(vars []config.Var) []VarDef {
+	defs := make([]VarDef, len(vars))
+	for i, v := range vars {
+		defs[i] = VarDef{
+			Name:      v.Name,
+			Prompt:    v.Prompt,
+			Help:      v.Help,
+			Default:   v.Default,
+			Env:       v.Env,
+			DependsOn: v.DependsOn,
+		}
+	}
+	return defs
+}
+
+// CyclicDependencyError is returned by ResolveVars when the DependsOn
+// graph contains a cycle.
+type CyclicDependencyError struct {
+	Vars []string
+}
+
+func (e CyclicDependencyError) Error() string {
+	return fmt.Sprintf("vars: cyclic dependency detected among: %v", e.Vars)
+}
+
+// UnknownDependencyError is returned when a VarDef's DependsOn references a
+// variable name that isn't declared.
+type UnknownDependencyError struct {
+	Var, DependsOn string
+}
+
+func (e UnknownDependencyError) Error() string {
+	return fmt.Sprintf("vars: %q depends on unknown var %q", e.Var, e.DependsOn)
+}
+
+// RequiredVarError is returned by ResolveVars when a var has no Default, no
+// matching Env override, and prompt is nil (i.e. a non-interactive run with
+// nothing to fall back on).
+type RequiredVarError struct {
+	Var string
+}
+
+func (e RequiredVarError) Error() string {
+	return fmt.Sprintf("vars: %q has no default or env override and this run is non-interactive; pass --var %s=... or set its env", e.Var, e.Var)
+}
+
+// Prompter resolves a single variable's value interactively, e.g. by asking
+// the user on the terminal.
+type Prompter func(v VarDef) (string, error)
+
+// ResolveVars resolves the given variable definitions into a map suitable
+// for merging into Template.fields as Vars, evaluating each Default against
+// t plus the vars resolved so far (so later defaults can reference earlier
+// ones), in topological order of DependsOn.
+//
+// env, if non-nil, is consulted for each VarDef.Env override before calling
+// prompt. prompt is nil in non-interactive runs (e.g. CI): in that mode, any
+// var left without a Default or Env override fails fast with
+// RequiredVarError instead of silently resolving to an empty string.
+func ResolveVars(t *Template, defs []VarDef, env map[string]string, prompt Prompter) (map[string]string, error) {
+	order, err := topoSortVars(defs)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]VarDef, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	resolved := map[string]string{}
+	for _, name := range order {
+		def := byName[name]
+
+		if env != nil && def.Env != "" {
+			if v, ok := env[def.Env]; ok {
+				resolved[name] = v
+				continue
+			}
+		}
+
+		if def.Default != "" {
+			scoped := t.clone().WithExtraFields(Fields{"Vars": resolved})
+			v, err := scoped.Apply(def.Default)
+			if err != nil {
+				return nil, fmt.Errorf("vars: failed to resolve default for %q: %w", name, err)
+			}
+			resolved[name] = v
+			continue
+		}
+
+		if prompt == nil {
+			return nil, RequiredVarError{Var: name}
+		}
+
+		v, err := prompt(def)
+		if err != nil {
+			return nil, fmt.Errorf("vars: failed to resolve %q: %w", name, err)
+		}
+		resolved[name] = v
+	}
+
+	return resolved, nil
+}
+
+// topoSortVars returns the var names in an order where every var comes
+// after everything in its DependsOn, detecting unknown and cyclic
+// dependencies along the way.
+func topoSortVars(defs []VarDef) ([]string, error) {
+	byName := make(map[string]VarDef, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+	for _, d := range defs {
+		for _, dep := range d.DependsOn {
+			if _, ok := byName[dep]; !ok {
+				return nil, UnknownDependencyError{Var: d.Name, DependsOn: dep}
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(defs))
+	order := make([]string, 0, len(defs))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return CyclicDependencyError{Vars: []string{name}}
+		}
+		state[name] = visiting
+		for _, dep := range byName[name].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, d := range defs {
+		if err := visit(d.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// WithVars merges resolved vars (as returned by ResolveVars) into the
+// template as .Vars.<Name>.
+func (t *Template) WithVars(resolved map[string]string) *Template {
+	return t.WithExtraFields(Fields{"Vars": resolved})
+}
+
+// clone returns a shallow copy of t, so intermediate Vars can be layered in
+// while resolving defaults without mutating the caller's Template.
+func (t *Template) clone() *Template {
+	fields := make(Fields, len(t.fields))
+	for k, v := range t.fields {
+		fields[k] = v
+	}
+	return &Template{fields: fields}
+}
@@ -0,0 +1,119 @@
+package tmpl
+
+import (
+	"testing"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+func TestResolveVarsDefaultsAndDependsOn(t *testing.T) {
+	tpl := newTestTemplate()
+
+	resolved, err := ResolveVars(tpl, []VarDef{
+		{Name: "Flavor", Default: "community"},
+		{Name: "ImageName", Default: "{{ .ProjectName }}-{{ .Vars.Flavor }}", DependsOn: []string{"Flavor"}},
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "community" {
+		t.Errorf("got Flavor=%q", resolved["Flavor"])
+	}
+	if resolved["ImageName"] != "foo-community" {
+		t.Errorf("got ImageName=%q", resolved["ImageName"])
+	}
+}
+
+func TestResolveVarsEnvOverride(t *testing.T) {
+	tpl := newTestTemplate()
+
+	resolved, err := ResolveVars(tpl, []VarDef{
+		{Name: "Flavor", Default: "community", Env: "FLAVOR"},
+	}, map[string]string{"FLAVOR": "enterprise"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "enterprise" {
+		t.Errorf("got Flavor=%q", resolved["Flavor"])
+	}
+}
+
+func TestResolveVarsFailsFastWhenNonInteractive(t *testing.T) {
+	tpl := newTestTemplate()
+
+	_, err := ResolveVars(tpl, []VarDef{{Name: "Flavor"}}, nil, nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, ok := err.(RequiredVarError); !ok {
+		t.Fatalf("expected RequiredVarError, got %T: %v", err, err)
+	}
+}
+
+func TestResolveVarsPromptsWhenInteractive(t *testing.T) {
+	tpl := newTestTemplate()
+
+	resolved, err := ResolveVars(tpl, []VarDef{{Name: "Flavor"}}, nil, func(v VarDef) (string, error) {
+		return "asked-" + v.Name, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved["Flavor"] != "asked-Flavor" {
+		t.Errorf("got %q", resolved["Flavor"])
+	}
+}
+
+func TestResolveVarsDetectsCycle(t *testing.T) {
+	tpl := newTestTemplate()
+
+	_, err := ResolveVars(tpl, []VarDef{
+		{Name: "A", Default: "{{ .Vars.B }}", DependsOn: []string{"B"}},
+		{Name: "B", Default: "{{ .Vars.A }}", DependsOn: []string{"A"}},
+	}, nil, nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, ok := err.(CyclicDependencyError); !ok {
+		t.Fatalf("expected CyclicDependencyError, got %T: %v", err, err)
+	}
+}
+
+func TestResolveVarsDetectsUnknownDependency(t *testing.T) {
+	tpl := newTestTemplate()
+
+	_, err := ResolveVars(tpl, []VarDef{
+		{Name: "A", Default: "x", DependsOn: []string{"Nope"}},
+	}, nil, nil)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if _, ok := err.(UnknownDependencyError); !ok {
+		t.Fatalf("expected UnknownDependencyError, got %T: %v", err, err)
+	}
+}
+
+func TestWithVarsMergesIntoFields(t *testing.T) {
+	tpl := newTestTemplate()
+	tpl.WithVars(map[string]string{"Flavor": "enterprise"})
+
+	out, err := tpl.Apply("{{ .Vars.Flavor }}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "enterprise" {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestVarDefsFromConfig(t *testing.T) {
+	defs := VarDefsFromConfig([]config.Var{
+		{Name: "Flavor", Default: "community", DependsOn: []string{"Other"}},
+	})
+	if len(defs) != 1 {
+		t.Fatalf("got %d defs", len(defs))
+	}
+	if defs[0].Name != "Flavor" || defs[0].Default != "community" || defs[0].DependsOn[0] != "Other" {
+		t.Errorf("unexpected conversion: %+v", defs[0])
+	}
+}
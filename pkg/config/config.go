@@ -0,0 +1,24 @@
+// Package config contains the data structures that represent a
+// .goreleaser.yaml project configuration.
+package config
+
+// Var describes a single user-declarable variable under the top-level
+// `vars:` section of .goreleaser.yaml.
+type Var struct {
+	Name      string   `yaml:"name"`
+	Prompt    string   `yaml:"prompt,omitempty"`
+	Help      string   `yaml:"help,omitempty"`
+	Default   string   `yaml:"default,omitempty"`
+	Env       string   `yaml:"env,omitempty"`
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// Project is the root goreleaser config.
+type Project struct {
+	ProjectName string `yaml:"project_name"`
+
+	// Vars lets users parameterize a single config for multiple release
+	// flavors (e.g. community vs enterprise) instead of maintaining one
+	// YAML file per flavor.
+	Vars []Var `yaml:"vars,omitempty"`
+}
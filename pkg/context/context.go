@@ -0,0 +1,68 @@
+// Package context wraps a context.Context and adds goreleaser-specific
+// config and state to it, so pipes can share data as a release run
+// progresses.
+package context
+
+import (
+	"context"
+	"time"
+
+	"github.com/goreleaser/goreleaser/pkg/config"
+)
+
+// GitInfo includes tags and diffs used in some point.
+type GitInfo struct {
+	CurrentTag  string
+	PreviousTag string
+	Branch      string
+	FullCommit  string
+	ShortCommit string
+	Commit      string
+	CommitDate  time.Time
+	URL         string
+
+	// IsDirty reports whether the working tree has uncommitted changes,
+	// as determined by `git status --porcelain`. It is populated once by
+	// the git pipe and cached here so later pipes (and tmpl.New) don't
+	// need to shell out again.
+	IsDirty bool
+
+	// TreeState is "dirty" or "clean", mirroring IsDirty. It exists
+	// alongside IsDirty so templates can use either a boolean or the
+	// ko-style string without extra plumbing.
+	TreeState string
+}
+
+// Semver represents a semantic version.
+type Semver struct {
+	Major      int64
+	Minor      int64
+	Patch      int64
+	Prerelease string
+}
+
+// Config is the root config, i.e. the parsed .goreleaser.yaml.
+type Config = config.Project
+
+// Context carries along some data through the pipes.
+type Context struct {
+	context.Context
+
+	Config   Config
+	Env      map[string]string
+	Git      GitInfo
+	Date     time.Time
+	Version  string
+	Semver   Semver
+	Snapshot bool
+}
+
+// New context.
+func New(cfg Config) *Context {
+	return &Context{
+		Context: context.Background(),
+		Config:  cfg,
+		Env:     map[string]string{},
+		Date:    time.Now(),
+	}
+}